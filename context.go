@@ -0,0 +1,16 @@
+package morfeusz
+
+import "context"
+
+// AnalyseStringCtx is like AnalyseString, but returns ctx.Err() if
+// ctx is already canceled before analysis starts. Analysis itself
+// runs in a single call into the underlying C++ library and cannot be
+// interrupted partway through, so this only bounds the time a caller
+// may have spent waiting to run, not the analysis itself.
+func (m Morfeusz) AnalyseStringCtx(
+	ctx context.Context, s string) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return m.AnalyseString(s), nil
+}
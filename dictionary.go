@@ -0,0 +1,67 @@
+package morfeusz
+
+import (
+	"errors"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// errDictionaryReloaded is returned by ResultsIterator.Err once the
+// Morfeusz instance that created it has reloaded its dictionary with
+// ReloadDictionary or SetUserDictionary and Next has been called.
+var errDictionaryReloaded = errors.New(
+	"Dictionary was reloaded; this ResultsIterator is no longer valid")
+
+// ReloadDictionary replaces the current dictionary with the one named
+// name, in place, without losing the dictionary search paths or the
+// charset, aggl, praet, case handling, and whitespace handling
+// currently in effect. Any ResultsIterator created from m before the
+// call returns false from its next call to Next, and its Err then
+// returns errDictionaryReloaded.
+func (m Morfeusz) ReloadDictionary(name string) error {
+	charset, caseHandling := m.Charset(), m.CaseHandling()
+	whitespaceHandling := m.WhitespaceHandling()
+	aggl, praet := m.Aggl(), m.Praet()
+	if err := m.SetDictionary(name); err != nil {
+		return err
+	}
+	if err := m.SetCharset(charset); err != nil {
+		return err
+	}
+	if err := m.SetCaseHandling(caseHandling); err != nil {
+		return err
+	}
+	if err := m.SetWhitespaceHandling(whitespaceHandling); err != nil {
+		return err
+	}
+	if aggl != "" {
+		if err := m.SetAggl(aggl); err != nil {
+			return err
+		}
+	}
+	if praet != "" {
+		if err := m.SetPraet(praet); err != nil {
+			return err
+		}
+	}
+	m.invalidateIterators()
+	return nil
+}
+
+// SetUserDictionary makes the dictionary at path the current
+// dictionary, first adding its directory to the dictionary search
+// paths so that SetDictionary can find it by name. Like
+// ReloadDictionary, it preserves the current setter state and
+// invalidates any live ResultsIterator.
+func (m Morfeusz) SetUserDictionary(path string) error {
+	m.PrependToDictionarySearchPaths(filepath.Dir(path))
+	return m.ReloadDictionary(filepath.Base(path))
+}
+
+func (m Morfeusz) invalidateIterators() {
+	atomic.AddUint32(m.gen, 1)
+}
+
+func (m Morfeusz) generation() uint32 {
+	return atomic.LoadUint32(m.gen)
+}
@@ -0,0 +1,224 @@
+package morfeusz
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// upos maps morfeusz coarse categories (the part of a tag before the
+// first colon, e.g. "subst" or "fin") to Universal Dependencies POS
+// tags. It is necessarily approximate: a single morfeusz category can
+// correspond to more than one UPOS depending on context, and unknown
+// categories map to "X".
+var upos = map[string]string{
+	"subst":   "NOUN",
+	"depr":    "NOUN",
+	"ger":     "NOUN",
+	"adj":     "ADJ",
+	"adja":    "ADJ",
+	"adjp":    "ADJ",
+	"adjc":    "ADJ",
+	"pact":    "ADJ",
+	"ppas":    "ADJ",
+	"adv":     "ADV",
+	"ppron12": "PRON",
+	"ppron3":  "PRON",
+	"siebie":  "PRON",
+	"num":     "NUM",
+	"numcol":  "NUM",
+	"fin":     "VERB",
+	"praet":   "VERB",
+	"impt":    "VERB",
+	"imps":    "VERB",
+	"inf":     "VERB",
+	"pcon":    "VERB",
+	"pant":    "VERB",
+	"winien":  "AUX",
+	"bedzie":  "AUX",
+	"prep":    "ADP",
+	"conj":    "CCONJ",
+	"comp":    "SCONJ",
+	"qub":     "PART",
+	"interj":  "INTJ",
+	"burk":    "INTJ",
+	"interp":  "PUNCT",
+	"sp":      "X",
+	"ign":     "X",
+	"brev":    "X",
+}
+
+// UPOS returns the Universal Dependencies POS tag for a morfeusz tag,
+// using the coarse category (the part before the first colon). It
+// returns "X" for categories it does not recognize.
+func UPOS(tag string) string {
+	coarse, _, _ := strings.Cut(tag, ":")
+	if u, ok := upos[coarse]; ok {
+		return u
+	}
+	return "X"
+}
+
+// tokenSource is satisfied by both Result and ResultsIterator,
+// letting the encoders below consume either a single analysis or a
+// stream of them uniformly.
+type tokenSource interface {
+	Next() bool
+	TokenInfo() *TokenInfo
+}
+
+// JSONEncoder writes TokenInfo values to an io.Writer as JSON, one
+// object per line. TokenInfo alone only carries numeric IDs for Tag,
+// Name, and Labels, so a JSONEncoder is created against the Morfeusz
+// instance needed to resolve them.
+type JSONEncoder struct {
+	w io.Writer
+	m *Morfeusz
+}
+
+// NewJSONEncoder returns a JSONEncoder that writes to w, resolving
+// tags, names, and labels against m.
+func NewJSONEncoder(w io.Writer, m *Morfeusz) *JSONEncoder {
+	return &JSONEncoder{w, m}
+}
+
+type jsonToken struct {
+	StartNode    int      `json:"start_node"`
+	EndNode      int      `json:"end_node"`
+	Orth         string   `json:"orth"`
+	Lemma        string   `json:"lemma"`
+	Tag          string   `json:"tag,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	Labels       []string `json:"labels,omitempty"`
+	IsIgn        bool     `json:"is_ign,omitempty"`
+	IsWhitespace bool     `json:"is_whitespace,omitempty"`
+}
+
+// Encode writes t to the encoder's io.Writer as a single JSON object
+// followed by a newline.
+func (e *JSONEncoder) Encode(t *TokenInfo) error {
+	b, err := json.Marshal(tokenToJSON(t, e.m))
+	if err != nil {
+		return err
+	}
+	_, err = e.w.Write(append(b, '\n'))
+	return err
+}
+
+// EncodeAll encodes every token remaining in src.
+func (e *JSONEncoder) EncodeAll(src tokenSource) error {
+	for src.Next() {
+		if err := e.Encode(src.TokenInfo()); err != nil {
+			return err
+		}
+	}
+	return sourceErr(src)
+}
+
+func tokenToJSON(t *TokenInfo, m *Morfeusz) jsonToken {
+	return jsonToken{
+		StartNode:    t.StartNode(),
+		EndNode:      t.EndNode(),
+		Orth:         t.Orth(),
+		Lemma:        t.Lemma(),
+		Tag:          t.Tag(m),
+		Name:         t.Name(m),
+		Labels:       t.Labels(m),
+		IsIgn:        t.IsIgn(),
+		IsWhitespace: t.IsWhitespace(),
+	}
+}
+
+// CoNLLUEncoder writes TokenInfo values to an io.Writer as CoNLL-U,
+// one line per token.
+type CoNLLUEncoder struct {
+	w    io.Writer
+	m    *Morfeusz
+	upos func(tag string) string
+}
+
+// NewCoNLLUEncoder returns a CoNLLUEncoder that writes to w,
+// resolving tags, names, and labels against m. It derives UPOS with
+// the package-level UPOS function; override that with SetUPOSFunc.
+func NewCoNLLUEncoder(w io.Writer, m *Morfeusz) *CoNLLUEncoder {
+	return &CoNLLUEncoder{w: w, m: m, upos: UPOS}
+}
+
+// SetUPOSFunc overrides the function used to derive the UPOS field
+// from a token's full morfeusz tag.
+func (e *CoNLLUEncoder) SetUPOSFunc(f func(tag string) string) {
+	e.upos = f
+}
+
+// Encode writes t as a single CoNLL-U line, numbered id.
+func (e *CoNLLUEncoder) Encode(id int, t *TokenInfo) error {
+	tag := t.Tag(e.m)
+	feats := "_"
+	if _, rest, ok := strings.Cut(tag, ":"); ok && rest != "" {
+		feats = strings.Join(strings.Split(rest, ":"), "|")
+	}
+	misc := "_"
+	if name := t.Name(e.m); name != "" {
+		misc = "Name=" + name
+	}
+	if labels := t.LabelsAsString(e.m); labels != "" {
+		if misc == "_" {
+			misc = "Labels=" + labels
+		} else {
+			misc += "|Labels=" + labels
+		}
+	}
+	_, err := fmt.Fprintf(e.w, "%d\t%s\t%s\t%s\t%s\t%s\t_\t_\t_\t%s\n",
+		id, t.Orth(), t.Lemma(), e.upos(tag), tag, feats, misc)
+	return err
+}
+
+// EncodeAll writes every token remaining in src, skipping whitespace
+// tokens and numbering the rest from 1, as CoNLL-U expects.
+func (e *CoNLLUEncoder) EncodeAll(src tokenSource) error {
+	id := 1
+	for src.Next() {
+		t := src.TokenInfo()
+		if t.IsWhitespace() {
+			continue
+		}
+		if err := e.Encode(id, t); err != nil {
+			return err
+		}
+		id++
+	}
+	return sourceErr(src)
+}
+
+// WriteCoNLLU writes every token remaining in r to w as CoNLL-U,
+// resolving tags, names, and labels against m. It is a convenience
+// wrapper around NewCoNLLUEncoder(w, m).EncodeAll(r).
+func (r Result) WriteCoNLLU(w io.Writer, m *Morfeusz) error {
+	return NewCoNLLUEncoder(w, m).EncodeAll(r)
+}
+
+// MarshalJSON implements json.Marshaler, resolving Tag, Name, and
+// Labels against the Morfeusz instance that produced t.
+func (t *TokenInfo) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tokenToJSON(t, t.m))
+}
+
+// MarshalJSON implements json.Marshaler, producing a JSON array of
+// every token remaining in r, resolving Tag, Name, and Labels against
+// the Morfeusz instance that produced r. Like TokenInfo, it modifies
+// the internals of r; call it at most once.
+func (r Result) MarshalJSON() ([]byte, error) {
+	tokens := []jsonToken{}
+	for r.Next() {
+		tokens = append(tokens, tokenToJSON(r.TokenInfo(), r.m))
+	}
+	return json.Marshal(tokens)
+}
+
+func sourceErr(src tokenSource) error {
+	if it, ok := src.(*ResultsIterator); ok {
+		return it.Err()
+	}
+	return nil
+}
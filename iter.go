@@ -0,0 +1,50 @@
+package morfeusz
+
+import "iter"
+
+// Tokens returns an iterator over the tokens in r, for use in a
+// range-over-func loop: for tok := range r.Tokens() { ... }. Ranging
+// over it consumes r exactly as repeated calls to Next/TokenInfo
+// would; do not mix the two styles of iteration over the same
+// Result. As with TokenInfo, a *TokenInfo yielded by the iterator
+// must not be retained past the end of the loop body.
+func (r Result) Tokens() iter.Seq[*TokenInfo] {
+	return func(yield func(*TokenInfo) bool) {
+		for r.Next() {
+			if !yield(r.TokenInfo()) {
+				return
+			}
+		}
+	}
+}
+
+// TokensByNode is like Tokens, but also yields each token's start
+// node.
+func (r Result) TokensByNode() iter.Seq2[int, *TokenInfo] {
+	return func(yield func(int, *TokenInfo) bool) {
+		for r.Next() {
+			t := r.TokenInfo()
+			if !yield(t.StartNode(), t) {
+				return
+			}
+		}
+	}
+}
+
+// GenerateSeq is like Generate, but returns an iterator over
+// (*TokenInfo, error) pairs instead of a slice. If generation fails
+// outright, the iterator yields a single (nil, err) pair.
+func (m Morfeusz) GenerateSeq(lemma string) iter.Seq2[*TokenInfo, error] {
+	return func(yield func(*TokenInfo, error) bool) {
+		ts, err := m.Generate(lemma)
+		if err != nil {
+			yield(nil, err)
+			return
+		}
+		for _, t := range ts {
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
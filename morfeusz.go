@@ -4,8 +4,8 @@
 //
 // The names of most methods correspond to the names in the C++ API
 // in an obvious way, with two major exceptions:
-//  * hasNext() and next() are renamed to Next() and TokenInfo(),
-//  * all getFoo() methods are renamed to Foo().
+//   - hasNext() and next() are renamed to Next() and TokenInfo(),
+//   - all getFoo() methods are renamed to Foo().
 package morfeusz
 
 /*
@@ -22,26 +22,42 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"iter"
 	"runtime"
 	"unsafe"
 )
 
 // Morfeusz is the type of a struct capable of morphological
-// analysis and/or generation.
+// analysis and/or generation. It wraps a stateful C++ object and is
+// not safe for concurrent use: do not call Analyse, AnalyseString,
+// Generate, or any setter on the same Morfeusz from more than one
+// goroutine at a time. Use Clone to give each goroutine its own
+// instance, or use a Pool.
 type Morfeusz struct {
 	morf C.Morf
+	// gen is shared by every copy of a given Morfeusz value and is
+	// bumped by ReloadDictionary and SetUserDictionary, so that any
+	// ResultsIterator created before the reload can notice it.
+	gen *uint32
 }
 
 // Result is the type of a struct representing the result
 // of morphological analysis of a text.
 type Result struct {
 	res C.Res
+	// m is the Morfeusz instance that produced res, retained so that
+	// Result can implement json.Marshaler without requiring the
+	// caller to supply it again; see MarshalJSON in encoding.go.
+	m *Morfeusz
 }
 
 // TokenInfo is the type of a struct representing the morphological
 // interpretation of a token in the result of morphological analysis.
 type TokenInfo struct {
 	info C.struct_TokenInfo
+	// m is the Morfeusz instance that produced info; see the note on
+	// Result.m.
+	m *Morfeusz
 }
 
 type (
@@ -183,10 +199,10 @@ func New(c *Config) (*Morfeusz, error) {
 	return ret, nil
 }
 
-// Analyse returns the result of morphological analysis
+// AnalyseBytes returns the result of morphological analysis
 // of a byte slice. Use the Next and TokenInfo functions
 // of the result to get the interpretation of the tokens.
-func (m Morfeusz) Analyse(text []byte) *Result {
+func (m Morfeusz) AnalyseBytes(text []byte) *Result {
 	return m.AnalyseString(string(text))
 }
 
@@ -202,7 +218,7 @@ func (m Morfeusz) AnalyseString(text string) *Result {
 	// Make sure that the associated C++ object r
 	// will be freed when the returned *Result
 	// is garbage-collected.
-	return gcResult(r)
+	return gcResult(r, &m)
 }
 
 // Next returns true when there is more information
@@ -223,7 +239,79 @@ func (r Result) TokenInfo() *TokenInfo {
 	// Make sure that the associated C++ object t and its
 	// character arrays will be freed when the returned
 	// *TokenInfo is garbage-collected.
-	return gcTokenInfo(t)
+	return gcTokenInfo(t, r.m)
+}
+
+// DAG is a typed view of the directed acyclic graph of tokens that a
+// Result represents, indexed by the node numbers in
+// TokenInfo.StartNode and TokenInfo.EndNode. It lets disambiguation
+// code compare alternative segmentations of the input instead of
+// consuming whatever single linearisation Next/TokenInfo happens to
+// produce.
+type DAG struct {
+	edges map[int][]*TokenInfo
+	nodes int
+}
+
+// NewDAG builds a DAG from every token remaining in r.
+func NewDAG(r *Result) *DAG {
+	d := &DAG{edges: map[int][]*TokenInfo{}}
+	for r.Next() {
+		t := r.TokenInfo()
+		d.edges[t.StartNode()] = append(d.edges[t.StartNode()], t)
+		if end := t.EndNode(); end+1 > d.nodes {
+			d.nodes = end + 1
+		}
+	}
+	return d
+}
+
+// Nodes returns the number of nodes in the graph.
+func (d *DAG) Nodes() int {
+	return d.nodes
+}
+
+// Edges returns the tokens that start at node, in no particular order.
+func (d *DAG) Edges(node int) []*TokenInfo {
+	return d.edges[node]
+}
+
+// Walk visits every edge of the graph in node order, calling visitor
+// for each, in the spirit of go/ast.Inspect. It stops as soon as
+// visitor returns false.
+func (d *DAG) Walk(visitor func(edge *TokenInfo) bool) {
+	for node := 0; node < d.nodes; node++ {
+		for _, t := range d.edges[node] {
+			if !visitor(t) {
+				return
+			}
+		}
+	}
+}
+
+// Paths returns an iterator over every segmentation of the input,
+// i.e. every path of edges from node 0 to the last node.
+func (d *DAG) Paths() iter.Seq[[]*TokenInfo] {
+	last := d.nodes - 1
+	return func(yield func([]*TokenInfo) bool) {
+		var path []*TokenInfo
+		var walk func(node int) bool
+		walk = func(node int) bool {
+			if node == last {
+				return yield(append([]*TokenInfo(nil), path...))
+			}
+			for _, t := range d.edges[node] {
+				path = append(path, t)
+				ok := walk(t.EndNode())
+				path = path[:len(path)-1]
+				if !ok {
+					return false
+				}
+			}
+			return true
+		}
+		walk(0)
+	}
 }
 
 // StartNode returns the index of the node where a token starts.
@@ -344,7 +432,7 @@ func (m Morfeusz) LabelsCount() int {
 // Generate returns a list of all inflected forms for a given lemma.
 func (m Morfeusz) Generate(lemma string) ([]*TokenInfo, error) {
 	return fromTokenInfoArray(C.generate(
-		m.morf, C.makeStructString(lemma)))
+		m.morf, C.makeStructString(lemma)), &m)
 }
 
 // GenerateWithTagID returns a list of inflected forms for a given lemma
@@ -352,7 +440,7 @@ func (m Morfeusz) Generate(lemma string) ([]*TokenInfo, error) {
 func (m Morfeusz) GenerateWithTagID(
 	tagID int, lemma string) ([]*TokenInfo, error) {
 	return fromTokenInfoArray(C.generateWithTagID(
-		m.morf, C.int(tagID), C.makeStructString(lemma)))
+		m.morf, C.int(tagID), C.makeStructString(lemma)), &m)
 }
 
 // DictID returns the ID of the current dictionary.
@@ -488,10 +576,13 @@ func (m Morfeusz) ClearDictionarySearchPaths() {
 
 // Clone copies an instance of Morfeusz. Beware: as of Morfeusz 1.9.16,
 // the copy and the original share the charset, token numbering, case
-// handling, whitespace handling, and dictionary search paths.
+// handling, whitespace handling, and dictionary search paths; they do
+// not share dictionary generation, so reloading one's dictionary with
+// ReloadDictionary does not invalidate the other's ResultsIterators.
 func (m Morfeusz) Clone() *Morfeusz {
 	// Make sure that the associated C++ object will be freed
-	// when the returned *Morfeusz is garbage-collected.
+	// when the returned *Morfeusz is garbage-collected. gcMorfeusz
+	// gives the clone its own generation counter.
 	return gcMorfeusz(C.cloneMorf(m.morf))
 }
 
@@ -511,19 +602,19 @@ func Copyright() string {
 }
 
 func gcMorfeusz(m C.Morf) *Morfeusz {
-	ret := &Morfeusz{m}
+	ret := &Morfeusz{morf: m, gen: new(uint32)}
 	runtime.SetFinalizer(ret, freeMorfeusz)
 	return ret
 }
 
-func gcResult(r C.Res) *Result {
-	ret := &Result{r}
+func gcResult(r C.Res, m *Morfeusz) *Result {
+	ret := &Result{r, m}
 	runtime.SetFinalizer(ret, freeResult)
 	return ret
 }
 
-func gcTokenInfo(t C.struct_TokenInfo) *TokenInfo {
-	ret := &TokenInfo{t}
+func gcTokenInfo(t C.struct_TokenInfo, m *Morfeusz) *TokenInfo {
+	ret := &TokenInfo{t, m}
 	runtime.SetFinalizer(ret, freeTokenInfo)
 	return ret
 }
@@ -551,7 +642,8 @@ func fromStringArray(arr C.struct_StringArray) []string {
 	return ret
 }
 
-func fromTokenInfoArray(arr C.struct_TokenInfoArray) ([]*TokenInfo, error) {
+func fromTokenInfoArray(
+	arr C.struct_TokenInfoArray, m *Morfeusz) ([]*TokenInfo, error) {
 	if arr.error.p != nil {
 		return nil, newError(arr.error)
 	}
@@ -559,7 +651,7 @@ func fromTokenInfoArray(arr C.struct_TokenInfoArray) ([]*TokenInfo, error) {
 		unsafe.Pointer(arr.tokens))[:arr.length:arr.length]
 	ret := make([]*TokenInfo, 0, arr.length)
 	for _, t := range sliceView {
-		ret = append(ret, gcTokenInfo(t))
+		ret = append(ret, gcTokenInfo(t, m))
 	}
 	C.freeTokenInfoArray(&arr)
 	return ret, nil
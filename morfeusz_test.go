@@ -1,7 +1,12 @@
 package morfeusz_test
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/go-morfeusz/morfeusz"
@@ -226,6 +231,195 @@ func TestAnalyse(t *testing.T) {
 	assertEqualTokenInfoSlices(t, got, want)
 }
 
+func TestTokensSeq(t *testing.T) {
+	m, _ := morfeusz.New(nil)
+	r := m.AnalyseString("dom")
+	var got []string
+	for tok := range r.Tokens() {
+		got = append(got, tok.Orth())
+	}
+	assertNonEmpty(t, len(got))
+
+	r = m.AnalyseString("dom")
+	for node, tok := range r.TokensByNode() {
+		assertEqualInt(t, node, tok.StartNode())
+	}
+
+	var genErr error
+	sawToken := false
+	for tok, err := range m.GenerateSeq("dom") {
+		if err != nil {
+			genErr = err
+			break
+		}
+		sawToken = true
+		_ = tok
+	}
+	assertNoError(t, genErr)
+	if !sawToken {
+		t.Error("got no tokens from GenerateSeq; want at least one")
+	}
+}
+
+func TestAnalyseStringCtx(t *testing.T) {
+	m, _ := morfeusz.New(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r, err := m.AnalyseStringCtx(ctx, "dom")
+	assertNoError(t, err)
+	if !r.Next() {
+		t.Error("got Next() == false; want true")
+	}
+
+	cancel()
+	_, err = m.AnalyseStringCtx(ctx, "dom")
+	assertError(t, err)
+}
+
+func TestDAG(t *testing.T) {
+	m, _ := morfeusz.New(nil)
+	d := morfeusz.NewDAG(m.AnalyseString("bez"))
+	assertNonEmpty(t, d.Nodes())
+	assertNonEmpty(t, len(d.Edges(0)))
+
+	var walked int
+	d.Walk(func(edge *morfeusz.TokenInfo) bool {
+		walked++
+		return true
+	})
+	assertEqualInt(t, walked, len(d.Edges(0)))
+
+	var paths int
+	for path := range d.Paths() {
+		assertNonEmpty(t, len(path))
+		paths++
+	}
+	assertEqualInt(t, paths, len(d.Edges(0)))
+}
+
+func TestAnalyseReader(t *testing.T) {
+	m, _ := morfeusz.New(nil)
+	m.SetWhitespaceHandling(morfeusz.KeepWhitespaces)
+	it := m.Analyse(strings.NewReader("bez xyz"))
+	it.SetChunkSize(4)
+	var got []tokenInfo
+	for it.Next() {
+		got = append(got, expandTokenInfo(it.TokenInfo(), m))
+	}
+	assertNoError(t, it.Err())
+	want := []tokenInfo{
+		{0, 1, "bez", "bez:P", false, false,
+			"prep:gen:nwok", "", ""},
+		{0, 1, "bez", "bez:S", false, false,
+			"subst:sg:nom.acc:m3", "nazwa_pospolita", "bot."},
+		{0, 1, "bez", "beza", false, false,
+			"subst:pl:gen:f", "nazwa_pospolita", ""},
+		{1, 2, " ", " ", false, true, "sp", "", ""},
+		{2, 3, "xyz", "xyz", true, false, "ign", "", ""},
+	}
+	assertEqualTokenInfoSlices(t, got, want)
+}
+
+func TestAnalyseReaderNodeNumbering(t *testing.T) {
+	// Small enough a chunk size, and long enough an input with real
+	// sentence boundaries, that readChunk must split it into more
+	// than one chunk; otherwise this test would pass vacuously.
+	text := "Ala ma kota. Ala ma psa. Ala ma rybę."
+
+	t.Run("ContinuousNumbering", func(t *testing.T) {
+		m, _ := morfeusz.New(nil)
+		assertNoError(t, m.SetTokenNumbering(morfeusz.ContinuousNumbering))
+		it := m.Analyse(strings.NewReader(text))
+		it.SetChunkSize(8)
+		starts := collectStartNodes(t, it)
+		for i := 1; i < len(starts); i++ {
+			if starts[i] < starts[i-1] {
+				t.Errorf("got node number %d after %d; "+
+					"want node numbers to never decrease "+
+					"under ContinuousNumbering",
+					starts[i], starts[i-1])
+			}
+		}
+	})
+
+	t.Run("SeparateNumbering", func(t *testing.T) {
+		m, _ := morfeusz.New(nil) // SeparateNumbering is the default.
+		it := m.Analyse(strings.NewReader(text))
+		it.SetChunkSize(8)
+		starts := collectStartNodes(t, it)
+		sawReset := false
+		for i := 1; i < len(starts); i++ {
+			if starts[i] < starts[i-1] {
+				sawReset = true
+			}
+		}
+		if !sawReset {
+			t.Error("got no node-number reset across the stream; " +
+				"want SeparateNumbering to reset node numbers at " +
+				"each chunk boundary (i.e. want readChunk to have " +
+				"split the input into more than one chunk)")
+		}
+	})
+}
+
+func collectStartNodes(t *testing.T, it *morfeusz.ResultsIterator) []int {
+	var starts []int
+	for it.Next() {
+		starts = append(starts, it.TokenInfo().StartNode())
+	}
+	assertNoError(t, it.Err())
+	assertNonEmpty(t, len(starts))
+	return starts
+}
+
+func TestAnalyseReaderNoBoundary(t *testing.T) {
+	// A single unpunctuated run of letters, longer than the chunk
+	// size and containing no paragraph or sentence boundary at all:
+	// readChunk must read all the way to EOF rather than truncating
+	// mid-token at the chunkSize-bounded cutoff it used to have.
+	text := strings.Repeat("a", 50)
+	m, _ := morfeusz.New(nil)
+	it := m.Analyse(strings.NewReader(text))
+	it.SetChunkSize(8)
+
+	var orths []string
+	for it.Next() {
+		orths = append(orths, it.TokenInfo().Orth())
+	}
+	assertNoError(t, it.Err())
+	assertEqualStringSlices(t, orths, []string{text})
+}
+
+func TestScanner(t *testing.T) {
+	m, _ := morfeusz.New(nil)
+	s := m.NewScanner(strings.NewReader("Ala ma kota. Ala ma psa."))
+	var orths []string
+	var starts []int
+	for s.Scan() {
+		orths = append(orths, s.Token().Orth())
+		starts = append(starts, s.Token().StartNode())
+	}
+	assertNoError(t, s.Err())
+	// The Scanner yields every interpretation of every token, exactly
+	// as analysing each sentence on its own would.
+	ref, _ := morfeusz.New(nil)
+	var want []string
+	for _, sentence := range []string{"Ala ma kota. ", "Ala ma psa."} {
+		for _, ti := range analyseToTokenInfoSlice(t, ref, sentence) {
+			want = append(want, ti.orth)
+		}
+	}
+	assertEqualStringSlices(t, orths, want)
+
+	for i := 1; i < len(starts); i++ {
+		if starts[i] < starts[i-1] {
+			t.Errorf("got node number %d after %d; want node numbers "+
+				"to stay continuous across the chunk boundary "+
+				"between the two sentences", starts[i], starts[i-1])
+		}
+	}
+}
+
 func TestGenerate(t *testing.T) {
 	m, _ := morfeusz.New(nil)
 	np := "nazwa_pospolita"
@@ -320,6 +514,62 @@ func TestGenerate(t *testing.T) {
 	}
 }
 
+func TestEncoders(t *testing.T) {
+	m, _ := morfeusz.New(nil)
+
+	t.Run("JSON", func(t *testing.T) {
+		var buf strings.Builder
+		enc := morfeusz.NewJSONEncoder(&buf, m)
+		assertNoError(t, enc.EncodeAll(m.AnalyseString("dom")))
+		if !strings.Contains(buf.String(), `"orth":"dom"`) {
+			t.Errorf("got %q; want it to contain orth=dom", buf.String())
+		}
+	})
+
+	t.Run("CoNLLU", func(t *testing.T) {
+		var buf strings.Builder
+		enc := morfeusz.NewCoNLLUEncoder(&buf, m)
+		assertNoError(t, enc.EncodeAll(m.AnalyseString("dom")))
+		lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+		assertNonEmpty(t, len(lines))
+		fields := strings.Split(lines[0], "\t")
+		assertEqualInt(t, len(fields), 10)
+		assertEqualString(t, fields[0], "1")
+		assertEqualString(t, fields[1], "dom")
+	})
+
+	t.Run("UPOS", func(t *testing.T) {
+		assertEqualString(t, morfeusz.UPOS("subst:sg:nom:m3"), "NOUN")
+		assertEqualString(t, morfeusz.UPOS("xyz"), "X")
+	})
+
+	t.Run("ResultWriteCoNLLU", func(t *testing.T) {
+		var buf strings.Builder
+		assertNoError(t, m.AnalyseString("dom").WriteCoNLLU(&buf, m))
+		assertNonEmpty(t, len(buf.String()))
+	})
+
+	t.Run("ResultMarshalJSON", func(t *testing.T) {
+		b, err := json.Marshal(m.AnalyseString("dom"))
+		assertNoError(t, err)
+		if !strings.Contains(string(b), `"orth":"dom"`) {
+			t.Errorf("got %q; want it to contain orth=dom", b)
+		}
+	})
+
+	t.Run("TokenInfoMarshalJSON", func(t *testing.T) {
+		r := m.AnalyseString("dom")
+		if !r.Next() {
+			t.Fatal("got Next() == false; want true")
+		}
+		b, err := json.Marshal(r.TokenInfo())
+		assertNoError(t, err)
+		if !strings.Contains(string(b), `"orth":"dom"`) {
+			t.Errorf("got %q; want it to contain orth=dom", b)
+		}
+	})
+}
+
 func TestUsage(t *testing.T) {
 	ma, _ := morfeusz.New(&morfeusz.Config{Usage: morfeusz.AnalyseOnly})
 	_, err := ma.Generate("dom")
@@ -358,6 +608,57 @@ func TestDictionarySearchPaths(t *testing.T) {
 	assertEmpty(t, len(m.DictionarySearchPaths()))
 }
 
+func TestReloadDictionary(t *testing.T) {
+	m, _ := morfeusz.New(nil)
+	assertNoError(t, m.SetAggl("permissive"))
+
+	it := m.Analyse(strings.NewReader("dom dom dom"))
+	it.SetChunkSize(4)
+	if !it.Next() {
+		t.Fatal("got Next() == false; want true")
+	}
+
+	assertNoError(t, m.ReloadDictionary(morfeusz.DefaultDictName()))
+	assertEqualString(t, m.Aggl(), "permissive")
+
+	for it.Next() {
+	}
+	if it.Err() == nil {
+		t.Error("got Err() == nil; want an error after ReloadDictionary")
+	}
+}
+
+func TestSetUserDictionary(t *testing.T) {
+	m, _ := morfeusz.New(nil)
+	paths := m.DictionarySearchPaths()
+	if len(paths) == 0 {
+		t.Skip("no dictionary search paths to build a dictionary path from")
+	}
+	assertNoError(t, m.SetAggl("permissive"))
+	dictPath := filepath.Join(paths[0], morfeusz.DefaultDictName())
+
+	assertNoError(t, m.SetUserDictionary(dictPath))
+
+	wantDir := filepath.Dir(dictPath)
+	found := false
+	for _, p := range m.DictionarySearchPaths() {
+		if p == wantDir {
+			found = true
+			break
+		}
+	}
+	if !found {
+		t.Errorf("got search paths %v; want them to contain %q",
+			m.DictionarySearchPaths(), wantDir)
+	}
+
+	// The setter state set before the reload must have survived it,
+	// and the dictionary must actually have loaded (not just left the
+	// old one in place).
+	assertEqualString(t, m.Aggl(), "permissive")
+	assertNonEmpty(t, len(analyseToTokenInfoSlice(t, m, "dom")))
+}
+
 func TestClone(t *testing.T) {
 	m, _ := morfeusz.New(nil)
 	c := m.Clone()
@@ -377,6 +678,34 @@ func TestClone(t *testing.T) {
 	assertEqualTokenInfoSlices(t, tGot, tWant)
 }
 
+func TestPool(t *testing.T) {
+	p, err := morfeusz.NewPool(nil, 2)
+	assertNoError(t, err)
+	defer p.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assertNonEmpty(t, len(p.Analyse("dom")))
+		}()
+	}
+	wg.Wait()
+
+	gen, err := p.Generate("dom")
+	assertNoError(t, err)
+	assertNonEmpty(t, len(gen))
+
+	assertNoError(t, p.AnalyseFunc("dom", func(ti *morfeusz.TokenInfo) error {
+		assertNotEqualString(t, ti.Orth(), "")
+		return nil
+	}))
+
+	_, err = morfeusz.NewPool(nil, 0)
+	assertError(t, err)
+}
+
 func expandTokenInfo(
 	t *morfeusz.TokenInfo, m *morfeusz.Morfeusz) tokenInfo {
 	// Check against double freeing of the underlying C.struct_String.
@@ -0,0 +1,113 @@
+package morfeusz
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Pool maintains a fixed-size set of cloned Morfeusz instances,
+// seeded from a template Config, so that multiple goroutines can
+// perform analysis and generation concurrently without each having
+// to hand-manage its own clone via Clone.
+type Pool struct {
+	free chan *Morfeusz
+	size int
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// NewPool returns a Pool of size instances of Morfeusz, each
+// configured like New(cfg) and sharing cfg's dictionary. Call Close
+// once the pool is no longer needed.
+func NewPool(cfg *Config, size int) (*Pool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("Invalid pool size %d", size)
+	}
+	base, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p := &Pool{free: make(chan *Morfeusz, size), size: size}
+	p.free <- base
+	for i := 1; i < size; i++ {
+		p.free <- base.Clone()
+	}
+	return p, nil
+}
+
+// Get removes and returns an instance of Morfeusz from the pool,
+// blocking until one is available. Return it with Put when done.
+func (p *Pool) Get() *Morfeusz {
+	return <-p.free
+}
+
+// Put returns an instance of Morfeusz previously obtained from Get
+// back to the pool.
+func (p *Pool) Put(m *Morfeusz) {
+	p.mu.Lock()
+	closed := p.closed
+	p.mu.Unlock()
+	if closed {
+		freeMorfeusz(m)
+		runtime.SetFinalizer(m, nil)
+		return
+	}
+	p.free <- m
+}
+
+// Analyse acquires an instance of Morfeusz from the pool, analyses
+// text with it, and returns the resulting tokens after returning the
+// instance to the pool.
+func (p *Pool) Analyse(text string) []*TokenInfo {
+	m := p.Get()
+	defer p.Put(m)
+	r := m.AnalyseString(text)
+	var ret []*TokenInfo
+	for r.Next() {
+		ret = append(ret, r.TokenInfo())
+	}
+	return ret
+}
+
+// AnalyseFunc acquires an instance of Morfeusz from the pool and
+// calls fn with every token produced by analysing text, stopping and
+// returning the first error fn returns.
+func (p *Pool) AnalyseFunc(text string, fn func(*TokenInfo) error) error {
+	m := p.Get()
+	defer p.Put(m)
+	r := m.AnalyseString(text)
+	for r.Next() {
+		if err := fn(r.TokenInfo()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Generate acquires an instance of Morfeusz from the pool and
+// generates the inflected forms of lemma with it.
+func (p *Pool) Generate(lemma string) ([]*TokenInfo, error) {
+	m := p.Get()
+	defer p.Put(m)
+	return m.Generate(lemma)
+}
+
+// Close drains the pool and frees every cloned instance of Morfeusz
+// currently checked in. Instances still checked out via Get are
+// freed as soon as they are returned with Put.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	p.closed = true
+	p.mu.Unlock()
+	for i := 0; i < p.size; i++ {
+		select {
+		case m := <-p.free:
+			freeMorfeusz(m)
+			runtime.SetFinalizer(m, nil)
+		default:
+			return
+		}
+	}
+}
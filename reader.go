@@ -0,0 +1,143 @@
+package morfeusz
+
+import (
+	"bufio"
+	"io"
+)
+
+// defaultChunkSize is the approximate number of bytes read from an
+// io.Reader before each chunk is handed to the underlying analyser.
+const defaultChunkSize = 64 * 1024
+
+// ResultsIterator is the result of morphological analysis of an
+// io.Reader, read and analysed incrementally rather than all at once.
+// Use Next and TokenInfo exactly as with Result.
+type ResultsIterator struct {
+	m         Morfeusz
+	r         *bufio.Reader
+	chunkSize int
+	cur       *Result
+	done      bool
+	err       error
+	startGen  uint32
+}
+
+// Analyse returns the result of morphological analysis of r, read
+// and analysed in chunks rather than all at once. If m's
+// TokenNumbering is ContinuousNumbering, node numbers stay continuous
+// across chunks, exactly as they would across successive calls to
+// AnalyseString on the same Morfeusz.
+func (m Morfeusz) Analyse(r io.Reader) *ResultsIterator {
+	return &ResultsIterator{
+		m:         m,
+		r:         bufio.NewReader(r),
+		chunkSize: defaultChunkSize,
+		startGen:  m.generation(),
+	}
+}
+
+// SetChunkSize overrides the approximate size, in bytes, of the
+// chunks read from the underlying io.Reader before each is analysed.
+// A call after Next has been called applies to the chunks read from
+// then on.
+func (it *ResultsIterator) SetChunkSize(n int) {
+	if n > 0 {
+		it.chunkSize = n
+	}
+}
+
+// Next reads and analyses as much of the underlying io.Reader as
+// needed to produce another piece of information, and returns true
+// when one is available. Like Result.Next, it does not consume the
+// piece of information it reports; TokenInfo does that.
+func (it *ResultsIterator) Next() bool {
+	for {
+		if it.m.generation() != it.startGen {
+			it.err = errDictionaryReloaded
+			it.done = true
+			return false
+		}
+		if it.cur != nil && it.cur.Next() {
+			return true
+		}
+		if it.done {
+			return false
+		}
+		chunk, err := it.readChunk()
+		if err != nil && err != io.EOF {
+			it.err = err
+			it.done = true
+		} else if err == io.EOF {
+			it.done = true
+		}
+		if len(chunk) == 0 {
+			return false
+		}
+		it.cur = it.m.AnalyseString(string(chunk))
+	}
+}
+
+// TokenInfo returns the next *TokenInfo, or nil if the analysis is
+// done. It modifies the internals of the iterator so that the next
+// call will return another piece of information.
+func (it *ResultsIterator) TokenInfo() *TokenInfo {
+	if it.cur == nil {
+		return nil
+	}
+	return it.cur.TokenInfo()
+}
+
+// Err returns the first error encountered while reading from the
+// underlying io.Reader, or nil if none occurred (io.EOF is not
+// reported as an error).
+func (it *ResultsIterator) Err() error {
+	return it.err
+}
+
+// readChunk reads roughly chunkSize bytes, then extends the read,
+// with no upper bound, up to the next paragraph or sentence boundary
+// so that analysis is never asked to segment a token split across two
+// chunks. A single unpunctuated chunk (a long log line, a URL, a
+// table row) is read in full rather than truncated mid-token; the
+// chunkSize given to SetChunkSize is therefore a target, not a cap.
+func (it *ResultsIterator) readChunk() ([]byte, error) {
+	buf := make([]byte, it.chunkSize)
+	n, err := io.ReadFull(it.r, buf)
+	buf = buf[:n]
+	if err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return buf, err
+	}
+	for !endsAtBoundary(buf) {
+		b, err := it.r.ReadByte()
+		if err != nil {
+			return buf, err
+		}
+		buf = append(buf, b)
+	}
+	return buf, nil
+}
+
+// endsAtBoundary reports whether buf ends at a paragraph break or
+// just past a sentence-terminating punctuation mark.
+func endsAtBoundary(buf []byte) bool {
+	n := len(buf)
+	if n >= 2 && buf[n-2] == '\n' && buf[n-1] == '\n' {
+		return true
+	}
+	if n < 2 {
+		return false
+	}
+	switch buf[n-1] {
+	case ' ', '\t', '\n':
+	default:
+		return false
+	}
+	switch buf[n-2] {
+	case '.', '!', '?':
+		return true
+	}
+	return false
+}
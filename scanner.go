@@ -0,0 +1,114 @@
+package morfeusz
+
+import (
+	"bufio"
+	"io"
+)
+
+// SplitFunc is the signature of the function used by a Scanner to
+// split its input into chunks before each is handed to the analyser.
+// It has the same contract as bufio.SplitFunc.
+type SplitFunc func(data []byte, atEOF bool) (advance int, token []byte, err error)
+
+// ScanSentences is the default SplitFunc used by NewScanner. It
+// advances past each run of text up to and including the whitespace
+// following a '.', '!', or '?', treating whatever is left at EOF as a
+// final chunk.
+func ScanSentences(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if atEOF && len(data) == 0 {
+		return 0, nil, nil
+	}
+	for i, b := range data {
+		switch b {
+		case '.', '!', '?':
+			j := i + 1
+			for j < len(data) && isSpace(data[j]) {
+				j++
+			}
+			if j == i+1 && j < len(data) {
+				// Not followed by whitespace, e.g. "3.14".
+				continue
+			}
+			if j < len(data) || atEOF {
+				return j, data[:j], nil
+			}
+		}
+	}
+	if atEOF {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+func isSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\n' || b == '\r'
+}
+
+// Scanner reads successive chunks of an io.Reader and analyses each
+// with Morfeusz, exposing the resulting tokens one at a time in the
+// style of bufio.Scanner.
+type Scanner struct {
+	m     Morfeusz
+	sc    *bufio.Scanner
+	split SplitFunc
+	cur   *Result
+	tok   *TokenInfo
+	err   error
+}
+
+// NewScanner returns a Scanner that reads from r, analysing it chunk
+// by chunk rather than all at once. It sets m's TokenNumbering to
+// ContinuousNumbering for the lifetime of the Scanner, so that node
+// numbers stay meaningful across chunk boundaries; the previous
+// setting is not restored, as m is expected to be dedicated to the
+// Scanner once created.
+func (m Morfeusz) NewScanner(r io.Reader) *Scanner {
+	m.SetTokenNumbering(ContinuousNumbering)
+	s := &Scanner{m: m, split: ScanSentences}
+	s.sc = bufio.NewScanner(r)
+	s.sc.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		return s.split(data, atEOF)
+	})
+	return s
+}
+
+// Buffer sets the initial buffer to use for scanning and the maximum
+// size of buffer that may be allocated during scanning, exactly like
+// bufio.Scanner.Buffer.
+func (s *Scanner) Buffer(buf []byte, max int) {
+	s.sc.Buffer(buf, max)
+}
+
+// SetSplitFunc overrides the function used to split the input into
+// chunks. It must be called before the first call to Scan.
+func (s *Scanner) SetSplitFunc(f SplitFunc) {
+	s.split = f
+}
+
+// Scan advances the Scanner to the next token, reading and analysing
+// further chunks of the underlying io.Reader as needed. It returns
+// false when there are no more tokens, either because the input is
+// exhausted or because an error occurred.
+func (s *Scanner) Scan() bool {
+	for {
+		if s.cur != nil && s.cur.Next() {
+			s.tok = s.cur.TokenInfo()
+			return true
+		}
+		if !s.sc.Scan() {
+			s.err = s.sc.Err()
+			return false
+		}
+		s.cur = s.m.AnalyseString(s.sc.Text())
+	}
+}
+
+// Token returns the token produced by the most recent call to Scan.
+func (s *Scanner) Token() *TokenInfo {
+	return s.tok
+}
+
+// Err returns the first non-EOF error encountered by the Scanner.
+func (s *Scanner) Err() error {
+	return s.err
+}